@@ -0,0 +1,318 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package turn
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+)
+
+// adminRequest is a single line-delimited JSON command sent to an AdminSocket.
+type adminRequest struct {
+	Command string          `json:"command"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// adminResponse is the line-delimited JSON reply to an adminRequest.
+type adminResponse struct {
+	OK    bool        `json:"ok"`
+	Error string      `json:"error,omitempty"`
+	Data  interface{} `json:"data,omitempty"`
+}
+
+// AllocationInfo is a read-only snapshot of an allocation, returned by the
+// listAllocations admin command.
+type AllocationInfo struct {
+	Username    string   `json:"username"`
+	ClientAddr  string   `json:"clientAddr"`
+	RelayAddr   string   `json:"relayAddr"`
+	BytesIn     uint64   `json:"bytesIn"`
+	BytesOut    uint64   `json:"bytesOut"`
+	Permissions []string `json:"permissions"`
+	Channels    []uint16 `json:"channels"`
+	ExpiresAt   string   `json:"expiresAt"`
+}
+
+// AdminSocket exposes a line-delimited JSON protocol on a net.Listener for
+// live introspection and management of a Server, analogous to yggdrasil's
+// admin socket. Every line written to a connection is a JSON object
+// (adminRequest); every reply is a single JSON object (adminResponse).
+type AdminSocket struct {
+	listener net.Listener
+	server   *Server
+
+	mu     sync.Mutex
+	closed bool
+}
+
+// newAdminSocket wraps listener and starts serving admin connections for s.
+func newAdminSocket(listener net.Listener, s *Server) (*AdminSocket, error) {
+	a := &AdminSocket{listener: listener, server: s}
+	go a.acceptLoop()
+	return a, nil
+}
+
+// Close stops accepting new admin connections.
+func (a *AdminSocket) Close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.closed {
+		return nil
+	}
+	a.closed = true
+	return a.listener.Close()
+}
+
+func (a *AdminSocket) acceptLoop() {
+	for {
+		conn, err := a.listener.Accept()
+		if err != nil {
+			return
+		}
+		go a.handleConn(conn)
+	}
+}
+
+func (a *AdminSocket) handleConn(conn net.Conn) {
+	defer conn.Close() //nolint:errcheck
+
+	scanner := bufio.NewScanner(conn)
+	enc := json.NewEncoder(conn)
+
+	for scanner.Scan() {
+		var req adminRequest
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			_ = enc.Encode(adminResponse{Error: fmt.Sprintf("invalid request: %s", err)})
+			continue
+		}
+
+		if err := enc.Encode(a.dispatch(req)); err != nil {
+			return
+		}
+	}
+}
+
+func (a *AdminSocket) dispatch(req adminRequest) adminResponse {
+	switch req.Command {
+	case "listAllocations":
+		return a.listAllocations()
+	case "closeAllocation":
+		return a.closeAllocation(req.Params)
+	case "listUsers":
+		return a.listUsers()
+	case "addUser":
+		return a.addUser(req.Params)
+	case "removeUser":
+		return a.removeUser(req.Params)
+	case "banPeer":
+		return a.banPeer(req.Params)
+	case "unbanPeer":
+		return a.unbanPeer(req.Params)
+	case "listBans":
+		return a.listBans()
+	case "getStats":
+		return a.getStats()
+	case "listPeers":
+		return a.listPeers()
+	default:
+		return adminResponse{Error: fmt.Sprintf("unknown command %q", req.Command)}
+	}
+}
+
+func (a *AdminSocket) listAllocations() adminResponse {
+	allocs := a.server.allocationManager.ListAllocations()
+	infos := make([]AllocationInfo, 0, len(allocs))
+	for _, alloc := range allocs {
+		bytesIn, bytesOut := alloc.BytesInOut()
+
+		perms := alloc.Permissions()
+		permStrs := make([]string, 0, len(perms))
+		for _, p := range perms {
+			permStrs = append(permStrs, p.String())
+		}
+
+		channels := alloc.ChannelBinds()
+		channelNums := make([]uint16, 0, len(channels))
+		for _, c := range channels {
+			channelNums = append(channelNums, c.Number)
+		}
+
+		infos = append(infos, AllocationInfo{
+			Username:    alloc.Username,
+			ClientAddr:  alloc.FiveTuple.SrcAddr.String(),
+			RelayAddr:   alloc.RelayAddr.String(),
+			BytesIn:     bytesIn,
+			BytesOut:    bytesOut,
+			Permissions: permStrs,
+			Channels:    channelNums,
+			ExpiresAt:   alloc.Expiry().Format(time.RFC3339),
+		})
+	}
+
+	return adminResponse{OK: true, Data: infos}
+}
+
+type closeAllocationParams struct {
+	FiveTuple string `json:"fiveTuple"`
+	Username  string `json:"username"`
+}
+
+func (a *AdminSocket) closeAllocation(raw json.RawMessage) adminResponse {
+	var params closeAllocationParams
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return adminResponse{Error: err.Error()}
+	}
+
+	var alloc *allocationLookupResult
+	switch {
+	case params.FiveTuple != "":
+		alloc = a.server.findAllocationByFiveTuple(params.FiveTuple)
+	case params.Username != "":
+		alloc = a.server.findAllocationByUsername(params.Username)
+	default:
+		return adminResponse{Error: "one of fiveTuple or username is required"}
+	}
+
+	if alloc == nil {
+		return adminResponse{Error: "allocation not found"}
+	}
+
+	a.server.allocationManager.DeleteAllocation(alloc.fiveTuple)
+	return adminResponse{OK: true}
+}
+
+func (a *AdminSocket) listUsers() adminResponse {
+	if a.server.authMap == nil {
+		return adminResponse{Error: "server has no mutable AuthMap configured"}
+	}
+	return adminResponse{OK: true, Data: a.server.authMap.ListUsers()}
+}
+
+type userParams struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+func (a *AdminSocket) addUser(raw json.RawMessage) adminResponse {
+	if a.server.authMap == nil {
+		return adminResponse{Error: "server has no mutable AuthMap configured"}
+	}
+
+	var params userParams
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return adminResponse{Error: err.Error()}
+	}
+
+	a.server.authMap.AddUser(params.Username, params.Password)
+	return adminResponse{OK: true}
+}
+
+func (a *AdminSocket) removeUser(raw json.RawMessage) adminResponse {
+	if a.server.authMap == nil {
+		return adminResponse{Error: "server has no mutable AuthMap configured"}
+	}
+
+	var params userParams
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return adminResponse{Error: err.Error()}
+	}
+
+	a.server.authMap.RemoveUser(params.Username)
+	return adminResponse{OK: true}
+}
+
+type banParams struct {
+	ClientIP string `json:"clientIP"`
+	PeerIP   string `json:"peerIP"`
+	Reason   string `json:"reason"`
+	Duration string `json:"duration"`
+}
+
+// parseBanIP parses s as an IP address if non-empty. An empty s is a
+// deliberate wildcard (BanStore treats a nil IP as "every client"/"every
+// peer"), but a non-empty s that fails to parse must be rejected rather
+// than silently falling back to that same wildcard.
+func parseBanIP(s string) (net.IP, error) {
+	if s == "" {
+		return nil, nil
+	}
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid IP %q", s)
+	}
+	return ip, nil
+}
+
+func (a *AdminSocket) banPeer(raw json.RawMessage) adminResponse {
+	var params banParams
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return adminResponse{Error: err.Error()}
+	}
+	if params.PeerIP == "" && params.ClientIP == "" {
+		return adminResponse{Error: "one of clientIP or peerIP is required"}
+	}
+
+	clientIP, err := parseBanIP(params.ClientIP)
+	if err != nil {
+		return adminResponse{Error: "invalid clientIP"}
+	}
+	peerIP, err := parseBanIP(params.PeerIP)
+	if err != nil {
+		return adminResponse{Error: "invalid peerIP"}
+	}
+
+	duration := time.Hour
+	if params.Duration != "" {
+		d, err := time.ParseDuration(params.Duration)
+		if err != nil {
+			return adminResponse{Error: err.Error()}
+		}
+		duration = d
+	}
+
+	if err := a.server.BanPeer(clientIP, peerIP, params.Reason, duration); err != nil {
+		return adminResponse{Error: err.Error()}
+	}
+	return adminResponse{OK: true}
+}
+
+func (a *AdminSocket) unbanPeer(raw json.RawMessage) adminResponse {
+	var params banParams
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return adminResponse{Error: err.Error()}
+	}
+
+	clientIP, err := parseBanIP(params.ClientIP)
+	if err != nil {
+		return adminResponse{Error: "invalid clientIP"}
+	}
+	peerIP, err := parseBanIP(params.PeerIP)
+	if err != nil {
+		return adminResponse{Error: "invalid peerIP"}
+	}
+
+	if err := a.server.UnbanPeer(clientIP, peerIP); err != nil {
+		return adminResponse{Error: err.Error()}
+	}
+	return adminResponse{OK: true}
+}
+
+func (a *AdminSocket) listBans() adminResponse {
+	return adminResponse{OK: true, Data: a.server.ListBans()}
+}
+
+func (a *AdminSocket) getStats() adminResponse {
+	return adminResponse{OK: true, Data: a.server.Stats()}
+}
+
+func (a *AdminSocket) listPeers() adminResponse {
+	if a.server.cluster == nil {
+		return adminResponse{Error: "server has no Cluster configured"}
+	}
+	return adminResponse{OK: true, Data: a.server.cluster.Peers()}
+}
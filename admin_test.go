@@ -0,0 +1,138 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package turn
+
+import (
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/itzloop/turn/v2/internal/allocation"
+)
+
+// newTestAdminSocket builds an AdminSocket around a minimal Server, without
+// newAdminSocket's background accept loop, so dispatch can be exercised
+// directly against a net.Listener-free Server.
+func newTestAdminSocket(s *Server) *AdminSocket {
+	return &AdminSocket{server: s}
+}
+
+func newTestServer() *Server {
+	return &Server{
+		allocationManager: allocation.NewManager(),
+		authMap:           NewAuthMap("example.org"),
+		banStore:          NewInMemoryBanStore(),
+	}
+}
+
+func dispatchOK(t *testing.T, a *AdminSocket, command string, params interface{}) adminResponse {
+	t.Helper()
+
+	var raw json.RawMessage
+	if params != nil {
+		raw = mustMarshal(t, params)
+	}
+
+	resp := a.dispatch(adminRequest{Command: command, Params: raw})
+	if !resp.OK {
+		t.Fatalf("%s: expected OK response, got error %q", command, resp.Error)
+	}
+	return resp
+}
+
+func mustMarshal(t *testing.T, v interface{}) json.RawMessage {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("failed to marshal: %s", err)
+	}
+	return b
+}
+
+func TestAdminSocketAddRemoveUserRoundTrip(t *testing.T) {
+	s := newTestServer()
+	a := newTestAdminSocket(s)
+
+	dispatchOK(t, a, "addUser", userParams{Username: "alice", Password: "hunter2"})
+
+	resp := dispatchOK(t, a, "listUsers", nil)
+	users, ok := resp.Data.([]string)
+	if !ok || len(users) != 1 || users[0] != "alice" {
+		t.Fatalf("listUsers returned %#v, want [\"alice\"]", resp.Data)
+	}
+
+	dispatchOK(t, a, "removeUser", userParams{Username: "alice"})
+
+	resp = dispatchOK(t, a, "listUsers", nil)
+	users, ok = resp.Data.([]string)
+	if !ok || len(users) != 0 {
+		t.Fatalf("listUsers returned %#v after removeUser, want empty", resp.Data)
+	}
+}
+
+func TestAdminSocketListAndCloseAllocation(t *testing.T) {
+	s := newTestServer()
+	a := newTestAdminSocket(s)
+
+	fiveTuple := &allocation.FiveTuple{
+		Protocol: allocation.UDP,
+		SrcAddr:  &net.UDPAddr{IP: net.ParseIP("203.0.113.1"), Port: 4000},
+		DstAddr:  &net.UDPAddr{IP: net.ParseIP("203.0.113.2"), Port: 3478},
+	}
+	relayAddr := &net.UDPAddr{IP: net.ParseIP("192.0.2.1"), Port: 50000}
+	s.allocationManager.CreateAllocation(fiveTuple, "alice", relayAddr, time.Minute)
+
+	resp := dispatchOK(t, a, "listAllocations", nil)
+	infos, ok := resp.Data.([]AllocationInfo)
+	if !ok || len(infos) != 1 || infos[0].Username != "alice" {
+		t.Fatalf("listAllocations returned %#v, want one allocation for alice", resp.Data)
+	}
+
+	dispatchOK(t, a, "closeAllocation", closeAllocationParams{Username: "alice"})
+
+	resp = dispatchOK(t, a, "listAllocations", nil)
+	infos, ok = resp.Data.([]AllocationInfo)
+	if !ok || len(infos) != 0 {
+		t.Fatalf("listAllocations returned %#v after closeAllocation, want none", resp.Data)
+	}
+}
+
+func TestAdminSocketBanUnbanPeerRoundTrip(t *testing.T) {
+	s := newTestServer()
+	a := newTestAdminSocket(s)
+
+	client := net.ParseIP("203.0.113.1")
+	peer := net.ParseIP("198.51.100.1")
+
+	dispatchOK(t, a, "banPeer", banParams{ClientIP: client.String(), PeerIP: peer.String(), Reason: "testing"})
+
+	if _, banned := s.IsBanned(client, peer); !banned {
+		t.Fatal("expected client to be banned for peer after banPeer")
+	}
+
+	dispatchOK(t, a, "unbanPeer", banParams{ClientIP: client.String(), PeerIP: peer.String()})
+
+	if _, banned := s.IsBanned(client, peer); banned {
+		t.Fatal("expected client to no longer be banned for peer after unbanPeer")
+	}
+}
+
+func TestAdminSocketBanPeerRejectsInvalidIP(t *testing.T) {
+	s := newTestServer()
+	a := newTestAdminSocket(s)
+
+	resp := a.dispatch(adminRequest{
+		Command: "banPeer",
+		Params:  mustMarshal(t, banParams{ClientIP: "203.0.113.1", PeerIP: "198.51.100.999"}),
+	})
+	if resp.OK || resp.Error != "invalid peerIP" {
+		t.Fatalf("got response %#v, want an \"invalid peerIP\" error", resp)
+	}
+
+	// The malformed peerIP must not have widened the ban to an unrelated peer.
+	if _, banned := s.IsBanned(net.ParseIP("203.0.113.1"), net.ParseIP("8.8.8.8")); banned {
+		t.Fatal("a rejected banPeer call must not ban any peer")
+	}
+}
@@ -0,0 +1,33 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package turn
+
+import "github.com/itzloop/turn/v2/internal/allocation"
+
+// allocationLookupResult carries just enough of an allocation.Allocation for
+// the admin socket to act on it without leaking internal/allocation types
+// into the public API.
+type allocationLookupResult struct {
+	fiveTuple *allocation.FiveTuple
+}
+
+// findAllocationByFiveTuple finds the allocation whose FiveTuple stringifies
+// to fiveTuple (the format produced by allocation.FiveTuple.String()).
+func (s *Server) findAllocationByFiveTuple(fiveTuple string) *allocationLookupResult {
+	for _, alloc := range s.allocationManager.ListAllocations() {
+		if alloc.FiveTuple.String() == fiveTuple {
+			return &allocationLookupResult{fiveTuple: alloc.FiveTuple}
+		}
+	}
+	return nil
+}
+
+// findAllocationByUsername finds the first allocation belonging to username.
+func (s *Server) findAllocationByUsername(username string) *allocationLookupResult {
+	alloc := s.allocationManager.GetAllocationByUsername(username)
+	if alloc == nil {
+		return nil
+	}
+	return &allocationLookupResult{fiveTuple: alloc.FiveTuple}
+}
@@ -0,0 +1,13 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package turn
+
+import "crypto/md5" //nolint:gosec
+
+// GenerateAuthKey generates the long-term credential key as defined in
+// RFC 5389 Section 15.4: MD5(username ":" realm ":" password).
+func GenerateAuthKey(username, realm, password string) []byte {
+	sum := md5.Sum([]byte(username + ":" + realm + ":" + password)) //nolint:gosec
+	return sum[:]
+}
@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package turn
+
+import (
+	"net"
+	"sync"
+)
+
+// AuthMap is a mutable, in-memory username -> key table that backs an
+// AuthHandler. It lets operators add and remove users at runtime, e.g. via
+// the admin socket's addUser/removeUser commands, without restarting the
+// Server.
+type AuthMap struct {
+	mu    sync.RWMutex
+	realm string
+	keys  map[string][]byte
+}
+
+// NewAuthMap creates an empty AuthMap for realm.
+func NewAuthMap(realm string) *AuthMap {
+	return &AuthMap{realm: realm, keys: map[string][]byte{}}
+}
+
+// AddUser adds or replaces the credentials for username.
+func (m *AuthMap) AddUser(username, password string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.keys[username] = GenerateAuthKey(username, m.realm, password)
+}
+
+// RemoveUser removes username, if present.
+func (m *AuthMap) RemoveUser(username string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.keys, username)
+}
+
+// ListUsers returns the currently configured usernames.
+func (m *AuthMap) ListUsers() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	out := make([]string, 0, len(m.keys))
+	for username := range m.keys {
+		out = append(out, username)
+	}
+	return out
+}
+
+// AuthHandler returns an AuthHandler backed by this AuthMap, suitable for use
+// as ServerConfig.AuthHandler.
+func (m *AuthMap) AuthHandler() AuthHandler {
+	return func(username, realm string, srcAddr net.Addr) ([]byte, bool) {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+		key, ok := m.keys[username]
+		return key, ok
+	}
+}
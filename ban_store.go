@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package turn
+
+import (
+	"net"
+	"time"
+)
+
+// BanEntry is a single peer ban. An empty ClientIP matches every client; an
+// empty PeerIP matches every peer (e.g. a client-wide ban triggered by a
+// bandwidth quota violation). At least one of the two must be set.
+type BanEntry struct {
+	ClientIP  string    `json:"clientIP"`
+	PeerIP    string    `json:"peerIP"`
+	Reason    string    `json:"reason"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+func (b BanEntry) expired(now time.Time) bool {
+	return now.After(b.ExpiresAt)
+}
+
+func (b BanEntry) matches(clientIP, peerIP net.IP) bool {
+	if b.PeerIP != "" && b.PeerIP != ipString(peerIP) {
+		return false
+	}
+	if b.ClientIP != "" && b.ClientIP != ipString(clientIP) {
+		return false
+	}
+	return true
+}
+
+// ipString returns ip.String(), or "" for a nil/wildcard IP.
+func ipString(ip net.IP) string {
+	if ip == nil {
+		return ""
+	}
+	return ip.String()
+}
+
+// BanStore is consulted by the Server before accepting CreatePermission and
+// ChannelBind requests (through the PermissionHandler it installs on each
+// PacketConnConfig); Allocate requests and Send/Data indications are not
+// checked against it by the Server. The default
+// implementation (NewInMemoryBanStore) keeps entries in memory only; use
+// NewFileBanStore, or implement BanStore yourself (e.g. backed by LevelDB),
+// for entries that must survive a restart.
+type BanStore interface {
+	// IsBanned reports whether peerIP is currently banned for clientIP,
+	// along with the reason if so.
+	IsBanned(clientIP, peerIP net.IP) (reason string, banned bool)
+
+	// Ban adds or replaces a ban. An empty clientIP bans peerIP for every
+	// client.
+	Ban(clientIP, peerIP net.IP, reason string, duration time.Duration) error
+
+	// Unban removes a ban previously added for (clientIP, peerIP).
+	Unban(clientIP, peerIP net.IP) error
+
+	// List returns every currently active (non-expired) ban.
+	List() []BanEntry
+
+	// Close releases any resources (e.g. an open file) held by the store.
+	Close() error
+}
+
+// BanPeer bans peerIP (for every client, or only clientIP if non-empty) for
+// duration, using the Server's configured BanStore.
+func (s *Server) BanPeer(clientIP, peerIP net.IP, reason string, duration time.Duration) error {
+	return s.banStore.Ban(clientIP, peerIP, reason, duration)
+}
+
+// UnbanPeer removes a ban previously added by BanPeer.
+func (s *Server) UnbanPeer(clientIP, peerIP net.IP) error {
+	return s.banStore.Unban(clientIP, peerIP)
+}
+
+// ListBans returns every currently active ban.
+func (s *Server) ListBans() []BanEntry {
+	return s.banStore.List()
+}
+
+// IsBanned reports whether peerIP is currently banned for clientIP.
+func (s *Server) IsBanned(clientIP, peerIP net.IP) (string, bool) {
+	return s.banStore.IsBanned(clientIP, peerIP)
+}
@@ -0,0 +1,196 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package turn
+
+import (
+	"net"
+	"sync"
+	"time"
+)
+
+// BanTriggers configures the thresholds at which the Server automatically
+// populates its BanStore, on top of whatever an operator adds manually (e.g.
+// via the admin socket).
+type BanTriggers struct {
+	// FailedAuthWindow/FailedAuthThreshold: if a source IP fails
+	// authentication FailedAuthThreshold times within FailedAuthWindow, that
+	// IP is banned (as a client, for every peer) for FailedAuthBanDuration.
+	FailedAuthWindow      time.Duration
+	FailedAuthThreshold   int
+	FailedAuthBanDuration time.Duration
+
+	// PermissionRejectThreshold: if a client's PermissionHandler rejects a
+	// CreatePermission/ChannelBind PermissionRejectThreshold times, it is
+	// banned. This is tracked two ways, either of which can trigger a ban:
+	// PermissionRejectThreshold rejections against the same peer bans that
+	// (client, peer) pair; rejections against PermissionRejectThreshold
+	// distinct peers ban the client for every peer (catching a client
+	// that scans many distinct peers rather than retrying one). Each
+	// subsequent violation of either kind doubles that counter's previous
+	// ban duration, starting at PermissionRejectBanDuration.
+	PermissionRejectThreshold   int
+	PermissionRejectBanDuration time.Duration
+
+	// BandwidthQuota: if an allocation relays more than BandwidthQuota bytes
+	// (in + out) over its lifetime, its client IP is banned for every peer
+	// for BandwidthBanDuration. This only takes effect if the relay data
+	// path calls Server.RecordAllocationBytes per message; see its doc
+	// comment.
+	BandwidthQuota       uint64
+	BandwidthBanDuration time.Duration
+}
+
+// DefaultBanTriggers returns reasonable defaults for BanTriggers.
+func DefaultBanTriggers() BanTriggers {
+	return BanTriggers{
+		FailedAuthWindow:            time.Minute,
+		FailedAuthThreshold:         5,
+		FailedAuthBanDuration:       time.Hour,
+		PermissionRejectThreshold:   3,
+		PermissionRejectBanDuration: 5 * time.Minute,
+		BandwidthQuota:              0, // disabled by default
+		BandwidthBanDuration:        time.Hour,
+	}
+}
+
+type rejectState struct {
+	count        int
+	lastDuration time.Duration
+}
+
+// banAutomation holds the mutable state backing BanTriggers.
+type banAutomation struct {
+	triggers BanTriggers
+
+	mu                sync.Mutex
+	failedAuthTimes   map[string][]time.Time
+	permissionRejects map[string]*rejectState
+
+	// clientRejectPeers tracks, per client, which peers it has already been
+	// counted against for the cross-peer escalation in
+	// recordPermissionReject, so repeated rejections against a single peer
+	// don't themselves inflate the cross-peer counter.
+	clientRejectPeers map[string]map[string]struct{}
+}
+
+func newBanAutomation(triggers BanTriggers) *banAutomation {
+	return &banAutomation{
+		triggers:          triggers,
+		failedAuthTimes:   map[string][]time.Time{},
+		permissionRejects: map[string]*rejectState{},
+		clientRejectPeers: map[string]map[string]struct{}{},
+	}
+}
+
+// recordFailedAuth is called by the request dispatch path whenever srcIP
+// fails authentication. Once FailedAuthThreshold failures land inside
+// FailedAuthWindow, srcIP is banned as a client (any peer).
+func (a *banAutomation) recordFailedAuth(store BanStore, srcIP net.IP) {
+	if a.triggers.FailedAuthThreshold <= 0 {
+		return
+	}
+
+	key := srcIP.String()
+	now := time.Now()
+
+	a.mu.Lock()
+	cutoff := now.Add(-a.triggers.FailedAuthWindow)
+	times := append(a.failedAuthTimes[key], now)
+	pruned := times[:0]
+	for _, t := range times {
+		if t.After(cutoff) {
+			pruned = append(pruned, t)
+		}
+	}
+	a.failedAuthTimes[key] = pruned
+	shouldBan := len(pruned) >= a.triggers.FailedAuthThreshold
+	if shouldBan {
+		delete(a.failedAuthTimes, key)
+	}
+	a.mu.Unlock()
+
+	if shouldBan {
+		_ = store.Ban(srcIP, nil, "too many failed authentication attempts", a.triggers.FailedAuthBanDuration)
+	}
+}
+
+// recordPermissionReject is called whenever a PermissionHandler rejects a
+// (clientIP, peerIP) pair. It escalates two ways, both with doubling
+// backoff: repeated rejections against the same peer ban that (client,
+// peer) pair; rejections against PermissionRejectThreshold *distinct* peers
+// ban the client for every peer (the permission-scanning pattern). A client
+// that only ever abuses one peer never advances the cross-peer counter, so
+// it stays scoped to that peer until it separately targets other peers too.
+func (a *banAutomation) recordPermissionReject(store BanStore, clientIP, peerIP net.IP) {
+	if a.triggers.PermissionRejectThreshold <= 0 {
+		return
+	}
+
+	pairKey := clientIP.String() + "->" + peerIP.String()
+	clientKey := clientIP.String()
+	peerKey := peerIP.String()
+
+	a.mu.Lock()
+	banPair, pairDuration := a.bumpRejectLocked(pairKey)
+
+	var banClient bool
+	var clientDuration time.Duration
+	seenPeers := a.clientRejectPeers[clientKey]
+	if seenPeers == nil {
+		seenPeers = map[string]struct{}{}
+		a.clientRejectPeers[clientKey] = seenPeers
+	}
+	if _, alreadyCounted := seenPeers[peerKey]; !alreadyCounted {
+		seenPeers[peerKey] = struct{}{}
+		banClient, clientDuration = a.bumpRejectLocked(clientKey)
+		if banClient {
+			// Start the distinct-peer set fresh once the client is banned,
+			// so the next escalation isn't pre-loaded with old peers.
+			delete(a.clientRejectPeers, clientKey)
+		}
+	}
+	a.mu.Unlock()
+
+	if banPair {
+		_ = store.Ban(clientIP, peerIP, "repeated permission rejections against the same peer", pairDuration)
+	}
+	if banClient {
+		_ = store.Ban(clientIP, nil, "repeated permission rejections across distinct peers", clientDuration)
+	}
+}
+
+// bumpRejectLocked increments the rejectState for key and reports whether it
+// has now crossed PermissionRejectThreshold, in which case it also resets
+// the counter and returns the (doubling) ban duration to apply. a.mu must be
+// held.
+func (a *banAutomation) bumpRejectLocked(key string) (shouldBan bool, duration time.Duration) {
+	state, ok := a.permissionRejects[key]
+	if !ok {
+		state = &rejectState{}
+		a.permissionRejects[key] = state
+	}
+	state.count++
+	if state.count < a.triggers.PermissionRejectThreshold {
+		return false, 0
+	}
+
+	if state.lastDuration == 0 {
+		duration = a.triggers.PermissionRejectBanDuration
+	} else {
+		duration = state.lastDuration * 2
+	}
+	state.lastDuration = duration
+	state.count = 0
+	return true, duration
+}
+
+// checkBandwidthQuota bans clientIP (for every peer) if bytesInOut exceeds
+// the configured BandwidthQuota. It is intended to be called whenever an
+// allocation's byte counters are updated.
+func (a *banAutomation) checkBandwidthQuota(store BanStore, clientIP net.IP, bytesInOut uint64) {
+	if a.triggers.BandwidthQuota == 0 || bytesInOut <= a.triggers.BandwidthQuota {
+		return
+	}
+	_ = store.Ban(clientIP, nil, "allocation exceeded bandwidth quota", a.triggers.BandwidthBanDuration)
+}
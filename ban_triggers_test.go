@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package turn
+
+import (
+	"net"
+	"testing"
+	"time"
+)
+
+func TestRecordPermissionRejectEscalatesAcrossDistinctPeers(t *testing.T) {
+	store := NewInMemoryBanStore()
+	auto := newBanAutomation(BanTriggers{
+		PermissionRejectThreshold:   3,
+		PermissionRejectBanDuration: time.Minute,
+	})
+
+	client := net.ParseIP("203.0.113.1")
+	peers := []net.IP{
+		net.ParseIP("198.51.100.1"),
+		net.ParseIP("198.51.100.2"),
+		net.ParseIP("198.51.100.3"),
+	}
+
+	for i := 0; i < len(peers)-1; i++ {
+		auto.recordPermissionReject(store, client, peers[i])
+		if _, banned := store.IsBanned(client, peers[i]); banned {
+			t.Fatalf("reject %d: a single rejection against a fresh peer should not ban anything yet", i)
+		}
+	}
+
+	auto.recordPermissionReject(store, client, peers[len(peers)-1])
+
+	// Three rejections against three distinct peers never repeats a pair,
+	// so this can only be the per-client (cross-peer) trigger, which bans
+	// the client for every peer rather than just the last one it hit.
+	unseenPeer := net.ParseIP("198.51.100.4")
+	if _, banned := store.IsBanned(client, unseenPeer); !banned {
+		t.Fatal("client should be banned for every peer after rejections spread across distinct peers")
+	}
+}
+
+func TestRecordPermissionRejectBansRepeatedPair(t *testing.T) {
+	store := NewInMemoryBanStore()
+	auto := newBanAutomation(BanTriggers{
+		PermissionRejectThreshold:   3,
+		PermissionRejectBanDuration: time.Minute,
+	})
+
+	client := net.ParseIP("203.0.113.1")
+	peer := net.ParseIP("198.51.100.1")
+
+	for i := 0; i < 2; i++ {
+		auto.recordPermissionReject(store, client, peer)
+	}
+	if _, banned := store.IsBanned(client, peer); banned {
+		t.Fatal("should not be banned before reaching PermissionRejectThreshold")
+	}
+
+	auto.recordPermissionReject(store, client, peer)
+	if _, banned := store.IsBanned(client, peer); !banned {
+		t.Fatal("should be banned once PermissionRejectThreshold is reached against the same peer")
+	}
+
+	unrelatedPeer := net.ParseIP("198.51.100.2")
+	if _, banned := store.IsBanned(client, unrelatedPeer); banned {
+		t.Fatal("repeated rejections against a single peer must not ban the client for unrelated peers")
+	}
+}
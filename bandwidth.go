@@ -0,0 +1,30 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package turn
+
+import (
+	"net"
+
+	"github.com/itzloop/turn/v2/internal/allocation"
+)
+
+// RecordAllocationBytes accounts for bytesIn/bytesOut relayed through alloc
+// and checks the result against BanTriggers.BandwidthQuota, banning the
+// allocation's client if it is exceeded.
+//
+// Nothing in this package calls this automatically: relay data (Send
+// indication / channel data) handling lives outside this package, so the
+// caller's data path must call RecordAllocationBytes itself after every
+// relayed message. Until it does, BandwidthQuota has no effect.
+func (s *Server) RecordAllocationBytes(alloc *allocation.Allocation, clientIP net.IP, bytesIn, bytesOut uint64) {
+	if bytesIn > 0 {
+		alloc.AddBytesIn(bytesIn)
+	}
+	if bytesOut > 0 {
+		alloc.AddBytesOut(bytesOut)
+	}
+
+	in, out := alloc.BytesInOut()
+	s.banAuto.checkBandwidthQuota(s.banStore, clientIP, in+out)
+}
@@ -0,0 +1,48 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package turn
+
+import (
+	iserver "github.com/itzloop/turn/v2/internal/server"
+)
+
+// BindingRateLimit configures the token-bucket limiter that
+// internal/server's Binding request handler applies per source prefix
+// (/32 for IPv4, /64 for IPv6), to stop a single attacker from using the
+// Binding path for reflection/amplification.
+type BindingRateLimit struct {
+	// RatePerSecond is the steady-state number of Binding requests a single
+	// prefix may send per second. Zero disables the limiter.
+	RatePerSecond float64
+
+	// Burst is the maximum number of Binding requests a prefix may send in a
+	// single burst. Defaults to RatePerSecond if zero.
+	Burst float64
+
+	// MaxEntries bounds the number of distinct prefixes tracked at once, so
+	// memory stays fixed even when an attacker spreads requests across many
+	// spoofed source addresses. Defaults to 65536 if zero.
+	MaxEntries int
+}
+
+// BindingRateLimiterStats is a point-in-time snapshot of a Server's
+// BindingRateLimit counters, exposed through the admin socket's getStats
+// command.
+type BindingRateLimiterStats struct {
+	Allowed uint64 `json:"allowed"`
+	Dropped uint64 `json:"dropped"`
+	Entries int    `json:"entries"`
+	Evicted uint64 `json:"evicted"`
+}
+
+func newBindingRateLimiter(config BindingRateLimit) *iserver.BindingRateLimiter {
+	if config.RatePerSecond <= 0 {
+		return nil
+	}
+	return iserver.NewBindingRateLimiter(iserver.BindingRateLimiterConfig{
+		RatePerSecond: config.RatePerSecond,
+		Burst:         config.Burst,
+		MaxEntries:    config.MaxEntries,
+	})
+}
@@ -0,0 +1,272 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package turn
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pion/logging"
+)
+
+// LoadReporter lets an operator feed their own load signal (e.g. active
+// allocations, relay port exhaustion, CPU) into a Cluster's redirect
+// decisions.
+type LoadReporter interface {
+	// Load returns a normalized load figure in [0, 1], where 1 means fully
+	// loaded.
+	Load() float64
+}
+
+// LoadReporterFunc adapts a plain function to a LoadReporter.
+type LoadReporterFunc func() float64
+
+// Load implements LoadReporter.
+func (f LoadReporterFunc) Load() float64 {
+	return f()
+}
+
+// RedirectThresholds configures when a Cluster decides the local Server is
+// overloaded and new Allocate requests should be redirected to a peer.
+type RedirectThresholds struct {
+	// Load is the LoadReporter.Load() value above which redirection kicks in.
+	Load float64
+}
+
+// PeerServer is a TURN server instance learned via DNS SRV discovery.
+type PeerServer struct {
+	// Addr is "host:port" as published by the SRV record, for display.
+	Addr string `json:"addr"`
+
+	// RedirectAddr is "ip:port", with the SRV target's address resolved and
+	// cached at SRV-refresh time, so PickRedirectTarget never has to
+	// perform DNS I/O from the (already overloaded) redirect path. Empty if
+	// resolution failed for this target on the most recent refresh.
+	RedirectAddr string `json:"redirectAddr,omitempty"`
+
+	Priority uint16    `json:"priority"`
+	Weight   uint16    `json:"weight"`
+	LastSeen time.Time `json:"lastSeen"`
+}
+
+// ClusterConfig configures a Cluster.
+type ClusterConfig struct {
+	// DNSSeeds are SRV names to resolve for peer discovery, e.g.
+	// "_turn._udp.example.org" or "_turns._tcp.example.org".
+	DNSSeeds []string
+
+	// SRVRefreshInterval is how often DNSSeeds are re-resolved. Defaults to
+	// 5 minutes.
+	SRVRefreshInterval time.Duration
+
+	// LoadReporter feeds the Server's current load into redirect decisions.
+	// Redirection is disabled if this is nil.
+	LoadReporter LoadReporter
+
+	// RedirectThresholds configures when redirection kicks in.
+	RedirectThresholds RedirectThresholds
+
+	LoggerFactory logging.LoggerFactory
+}
+
+// srvResolver is the subset of *net.Resolver that Cluster depends on, so
+// tests can substitute a fake.
+type srvResolver interface {
+	LookupSRV(ctx context.Context, service, proto, name string) (cname string, addrs []*net.SRV, err error)
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+// Cluster periodically resolves a set of DNS SRV seeds to discover peer TURN
+// servers, similar to how p2p bootnodes/DNS seeds distribute entry points.
+// Given a LoadReporter, it also decides when the local Server is overloaded
+// and should redirect clients to a lightly loaded peer via RFC 5766
+// Section 15 ALTERNATE-SERVER (error code 300).
+type Cluster struct {
+	config   ClusterConfig
+	log      logging.LeveledLogger
+	resolver srvResolver
+
+	mu    sync.RWMutex
+	peers map[string]PeerServer
+
+	// lastSeedPeers remembers the peers most recently discovered via each
+	// DNS seed, keyed by seed, so that a single seed's transient LookupSRV
+	// failure only risks staleness for that seed's own peers rather than
+	// wiping out peers contributed by seeds that still resolved fine.
+	lastSeedPeers map[string]map[string]PeerServer
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewCluster creates a Cluster and starts its background SRV resolver.
+func NewCluster(config ClusterConfig) *Cluster {
+	if config.SRVRefreshInterval <= 0 {
+		config.SRVRefreshInterval = 5 * time.Minute
+	}
+
+	loggerFactory := config.LoggerFactory
+	if loggerFactory == nil {
+		loggerFactory = logging.NewDefaultLoggerFactory()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	c := &Cluster{
+		config:        config,
+		log:           loggerFactory.NewLogger("turn-cluster"),
+		resolver:      net.DefaultResolver,
+		peers:         map[string]PeerServer{},
+		lastSeedPeers: map[string]map[string]PeerServer{},
+		cancel:        cancel,
+		done:          make(chan struct{}),
+	}
+
+	go c.run(ctx)
+
+	return c
+}
+
+func (c *Cluster) run(ctx context.Context) {
+	defer close(c.done)
+
+	c.resolveOnce(ctx)
+
+	ticker := time.NewTicker(c.config.SRVRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.resolveOnce(ctx)
+		}
+	}
+}
+
+func (c *Cluster) resolveOnce(ctx context.Context) {
+	discovered := map[string]PeerServer{}
+	now := time.Now()
+
+	if c.lastSeedPeers == nil {
+		c.lastSeedPeers = map[string]map[string]PeerServer{}
+	}
+
+	for _, seed := range c.config.DNSSeeds {
+		service, proto, name, err := splitSRVSeed(seed)
+		if err != nil {
+			c.log.Warnf("skipping invalid DNS seed %q: %s", seed, err)
+			continue
+		}
+
+		_, addrs, err := c.resolver.LookupSRV(ctx, service, proto, name)
+		if err != nil {
+			c.log.Warnf("SRV lookup for %q failed: %s; keeping its last known peers", seed, err)
+			for target, peer := range c.lastSeedPeers[seed] {
+				discovered[target] = peer
+			}
+			continue
+		}
+
+		seedPeers := make(map[string]PeerServer, len(addrs))
+		for _, addr := range addrs {
+			host := strings.TrimSuffix(addr.Target, ".")
+			target := net.JoinHostPort(host, strconv.Itoa(int(addr.Port)))
+
+			peer := PeerServer{
+				Addr:     target,
+				Priority: addr.Priority,
+				Weight:   addr.Weight,
+				LastSeen: now,
+			}
+
+			if ips, err := c.resolver.LookupIPAddr(ctx, host); err != nil || len(ips) == 0 {
+				c.log.Warnf("failed to resolve SRV target %q: %s", host, err)
+			} else {
+				peer.RedirectAddr = net.JoinHostPort(ips[0].IP.String(), strconv.Itoa(int(addr.Port)))
+			}
+
+			discovered[target] = peer
+			seedPeers[target] = peer
+		}
+		c.lastSeedPeers[seed] = seedPeers
+	}
+
+	if len(discovered) == 0 && len(c.config.DNSSeeds) > 0 {
+		c.log.Warnf("DNS refresh discovered no peers this round; keeping last known peer set")
+		return
+	}
+
+	c.mu.Lock()
+	c.peers = discovered
+	c.mu.Unlock()
+}
+
+// splitSRVSeed splits a seed like "_turn._udp.example.org" into the
+// service/proto/name triple expected by net.Resolver.LookupSRV.
+func splitSRVSeed(seed string) (service, proto, name string, err error) {
+	parts := strings.SplitN(seed, ".", 3)
+	if len(parts) != 3 || !strings.HasPrefix(parts[0], "_") || !strings.HasPrefix(parts[1], "_") {
+		return "", "", "", fmt.Errorf("expected _service._proto.name, got %q", seed)
+	}
+	return strings.TrimPrefix(parts[0], "_"), strings.TrimPrefix(parts[1], "_"), parts[2], nil
+}
+
+// Peers returns a snapshot of every currently known peer TURN server.
+func (c *Cluster) Peers() []PeerServer {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	out := make([]PeerServer, 0, len(c.peers))
+	for _, p := range c.peers {
+		out = append(out, p)
+	}
+	return out
+}
+
+// PickRedirectTarget returns a lightly loaded peer to redirect a client to,
+// if the local Server is over RedirectThresholds according to LoadReporter.
+// ok is false if redirection should not happen: no LoadReporter is
+// configured, load is within bounds, or no peers are currently known.
+func (c *Cluster) PickRedirectTarget() (target string, ok bool) {
+	if c.config.LoadReporter == nil {
+		return "", false
+	}
+	if c.config.LoadReporter.Load() <= c.config.RedirectThresholds.Load {
+		return "", false
+	}
+
+	peers := c.Peers()
+	var candidates []PeerServer
+	for _, p := range peers {
+		if p.RedirectAddr != "" {
+			candidates = append(candidates, p)
+		}
+	}
+	if len(candidates) == 0 {
+		return "", false
+	}
+
+	// Lowest SRV priority value wins, per RFC 2782.
+	best := candidates[0]
+	for _, p := range candidates[1:] {
+		if p.Priority < best.Priority {
+			best = p
+		}
+	}
+	return best.RedirectAddr, true
+}
+
+// Close stops the background SRV resolver.
+func (c *Cluster) Close() error {
+	c.cancel()
+	<-c.done
+	return nil
+}
@@ -0,0 +1,173 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package turn
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/pion/logging"
+)
+
+// fakeSRVResolver is a srvResolver that returns canned results instead of
+// doing real DNS I/O, so Cluster's peer selection/redirect logic can be
+// tested deterministically.
+type fakeSRVResolver struct {
+	srv map[string][]*net.SRV
+	ip  map[string][]net.IPAddr
+
+	srvErr map[string]error
+	ipErr  map[string]error
+}
+
+func (f *fakeSRVResolver) LookupSRV(_ context.Context, service, proto, name string) (string, []*net.SRV, error) {
+	key := service + "." + proto + "." + name
+	if err, ok := f.srvErr[key]; ok {
+		return "", nil, err
+	}
+	return "", f.srv[key], nil
+}
+
+func (f *fakeSRVResolver) LookupIPAddr(_ context.Context, host string) ([]net.IPAddr, error) {
+	if err, ok := f.ipErr[host]; ok {
+		return nil, err
+	}
+	return f.ip[host], nil
+}
+
+// newTestCluster builds a Cluster directly against resolver, without
+// NewCluster's background goroutine (which would otherwise race to resolve
+// against the real DNS resolver before the test can swap it out).
+func newTestCluster(resolver *fakeSRVResolver, seeds []string) *Cluster {
+	c := &Cluster{
+		config:   ClusterConfig{DNSSeeds: seeds},
+		log:      logging.NewDefaultLoggerFactory().NewLogger("turn-cluster-test"),
+		resolver: resolver,
+		peers:    map[string]PeerServer{},
+	}
+	c.resolveOnce(context.Background())
+	return c
+}
+
+func TestClusterPickRedirectTargetUsesResolvedIP(t *testing.T) {
+	resolver := &fakeSRVResolver{
+		srv: map[string][]*net.SRV{
+			"turn.udp.example.org": {
+				{Target: "b.example.org.", Port: 3478, Priority: 20},
+				{Target: "a.example.org.", Port: 3478, Priority: 10},
+			},
+		},
+		ip: map[string][]net.IPAddr{
+			"a.example.org": {{IP: net.ParseIP("192.0.2.10")}},
+			"b.example.org": {{IP: net.ParseIP("192.0.2.20")}},
+		},
+	}
+
+	c := newTestCluster(resolver, []string{"_turn._udp.example.org"})
+	c.config.LoadReporter = LoadReporterFunc(func() float64 { return 1 })
+	c.config.RedirectThresholds = RedirectThresholds{Load: 0.5}
+
+	target, ok := c.PickRedirectTarget()
+	if !ok {
+		t.Fatal("expected a redirect target")
+	}
+	// Lowest SRV priority (a.example.org, priority 10) should win, and the
+	// target must already be the cached IP literal, not the hostname.
+	if target != "192.0.2.10:3478" {
+		t.Fatalf("got target %q, want the resolved IP of the lowest-priority peer", target)
+	}
+}
+
+func TestClusterPickRedirectTargetSkipsUnresolvedPeers(t *testing.T) {
+	resolver := &fakeSRVResolver{
+		srv: map[string][]*net.SRV{
+			"turn.udp.example.org": {
+				{Target: "a.example.org.", Port: 3478, Priority: 10},
+				{Target: "b.example.org.", Port: 3478, Priority: 20},
+			},
+		},
+		ip: map[string][]net.IPAddr{
+			"b.example.org": {{IP: net.ParseIP("192.0.2.20")}},
+		},
+		ipErr: map[string]error{
+			"a.example.org": errors.New("no such host"),
+		},
+	}
+
+	c := newTestCluster(resolver, []string{"_turn._udp.example.org"})
+	c.config.LoadReporter = LoadReporterFunc(func() float64 { return 1 })
+	c.config.RedirectThresholds = RedirectThresholds{Load: 0.5}
+
+	target, ok := c.PickRedirectTarget()
+	if !ok {
+		t.Fatal("expected a redirect target")
+	}
+	if target != "192.0.2.20:3478" {
+		t.Fatalf("got target %q, want the only peer with a resolved address", target)
+	}
+}
+
+func TestClusterKeepsLastKnownPeersOnFailedRefresh(t *testing.T) {
+	resolver := &fakeSRVResolver{
+		srv: map[string][]*net.SRV{
+			"turn.udp.example.org": {
+				{Target: "a.example.org.", Port: 3478, Priority: 10},
+			},
+		},
+		ip: map[string][]net.IPAddr{
+			"a.example.org": {{IP: net.ParseIP("192.0.2.10")}},
+		},
+	}
+
+	c := newTestCluster(resolver, []string{"_turn._udp.example.org"})
+
+	if got := len(c.Peers()); got != 1 {
+		t.Fatalf("expected 1 peer after initial resolve, got %d", got)
+	}
+
+	// Every SRV lookup now fails, as on a transient resolver blip.
+	resolver.srvErr = map[string]error{
+		"turn.udp.example.org": errors.New("temporary failure in name resolution"),
+	}
+	c.resolveOnce(context.Background())
+
+	if got := len(c.Peers()); got != 1 {
+		t.Fatalf("peer set should be retained on a failed refresh, got %d peers", got)
+	}
+}
+
+func TestClusterKeepsPerSeedPeersWhenOnlyOneSeedFails(t *testing.T) {
+	resolver := &fakeSRVResolver{
+		srv: map[string][]*net.SRV{
+			"turn.udp.a.example.org": {
+				{Target: "a.example.org.", Port: 3478, Priority: 10},
+			},
+			"turn.udp.b.example.org": {
+				{Target: "b.example.org.", Port: 3478, Priority: 10},
+			},
+		},
+		ip: map[string][]net.IPAddr{
+			"a.example.org": {{IP: net.ParseIP("192.0.2.10")}},
+			"b.example.org": {{IP: net.ParseIP("192.0.2.20")}},
+		},
+	}
+
+	c := newTestCluster(resolver, []string{"_turn._udp.a.example.org", "_turn._udp.b.example.org"})
+
+	if got := len(c.Peers()); got != 2 {
+		t.Fatalf("expected 2 peers after initial resolve, got %d", got)
+	}
+
+	// Only the "a" seed blips; "b" still resolves fine.
+	resolver.srvErr = map[string]error{
+		"turn.udp.a.example.org": errors.New("temporary failure in name resolution"),
+	}
+	c.resolveOnce(context.Background())
+
+	if got := len(c.Peers()); got != 2 {
+		t.Fatalf("peer from the unaffected seed should not be lost alongside the blipping seed's peer, got %d peers", got)
+	}
+}
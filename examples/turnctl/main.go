@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+// Command turnctl is a small CLI that talks to a turn.Server's admin socket.
+// It sends a single line-delimited JSON command and prints the response.
+//
+//	turnctl -addr localhost:3479 -command listAllocations
+//	turnctl -addr localhost:3479 -command addUser -params '{"username":"alice","password":"hunter2"}'
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"os"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:3479", "Address of the turn.Server admin socket.")
+	command := flag.String("command", "", "Admin command to run, e.g. listAllocations.")
+	params := flag.String("params", "{}", "JSON-encoded params for the command.")
+	flag.Parse()
+
+	if *command == "" {
+		log.Fatal("'command' is required")
+	}
+
+	conn, err := net.Dial("tcp", *addr)
+	if err != nil {
+		log.Fatalf("Failed to connect to admin socket: %s", err)
+	}
+	defer conn.Close() //nolint:errcheck
+
+	req := struct {
+		Command string          `json:"command"`
+		Params  json.RawMessage `json:"params"`
+	}{
+		Command: *command,
+		Params:  json.RawMessage(*params),
+	}
+
+	enc := json.NewEncoder(conn)
+	if err := enc.Encode(req); err != nil {
+		log.Fatalf("Failed to send command: %s", err)
+	}
+
+	scanner := bufio.NewScanner(conn)
+	if !scanner.Scan() {
+		log.Fatalf("No response from admin socket: %s", scanner.Err())
+	}
+
+	var pretty map[string]interface{}
+	if err := json.Unmarshal(scanner.Bytes(), &pretty); err != nil {
+		log.Fatalf("Failed to decode response: %s", err)
+	}
+
+	enc2 := json.NewEncoder(os.Stdout)
+	enc2.SetIndent("", "  ")
+	if err := enc2.Encode(pretty); err != nil {
+		fmt.Println(string(scanner.Bytes()))
+	}
+}
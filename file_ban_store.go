@@ -0,0 +1,103 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package turn
+
+import (
+	"encoding/json"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+// FileBanStore is a BanStore that persists its entries to a JSON file on
+// disk, so bans survive a server restart. It is a thin wrapper around
+// InMemoryBanStore that flushes to disk after every mutation; operators
+// wanting a heavier-duty backend (e.g. LevelDB) can implement BanStore
+// directly instead.
+type FileBanStore struct {
+	path string
+
+	mu  sync.Mutex
+	mem *InMemoryBanStore
+}
+
+// NewFileBanStore loads any previously persisted, still-active entries from
+// the ban file at path. A missing file is not an error; the file is created
+// on the first Ban or Unban.
+func NewFileBanStore(path string) (*FileBanStore, error) {
+	s := &FileBanStore{path: path, mem: NewInMemoryBanStore()}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, err
+	}
+
+	var entries []BanEntry
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &entries); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	for _, entry := range entries {
+		if entry.expired(now) {
+			continue
+		}
+		s.mem.entries[banKey(net.ParseIP(entry.ClientIP), net.ParseIP(entry.PeerIP))] = entry
+	}
+
+	return s, nil
+}
+
+// IsBanned implements BanStore.
+func (s *FileBanStore) IsBanned(clientIP, peerIP net.IP) (string, bool) {
+	return s.mem.IsBanned(clientIP, peerIP)
+}
+
+// Ban implements BanStore.
+func (s *FileBanStore) Ban(clientIP, peerIP net.IP, reason string, duration time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.mem.Ban(clientIP, peerIP, reason, duration); err != nil {
+		return err
+	}
+	return s.flush()
+}
+
+// Unban implements BanStore.
+func (s *FileBanStore) Unban(clientIP, peerIP net.IP) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.mem.Unban(clientIP, peerIP); err != nil {
+		return err
+	}
+	return s.flush()
+}
+
+// List implements BanStore.
+func (s *FileBanStore) List() []BanEntry {
+	return s.mem.List()
+}
+
+// Close implements BanStore.
+func (s *FileBanStore) Close() error {
+	return nil
+}
+
+// flush rewrites the ban file with the current contents of s.mem. Callers
+// must hold s.mu.
+func (s *FileBanStore) flush() error {
+	data, err := json.Marshal(s.mem.List())
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0o600)
+}
@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package turn
+
+import (
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileBanStoreReloadsAfterRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bans.json")
+
+	client := net.ParseIP("203.0.113.1")
+	peer := net.ParseIP("198.51.100.1")
+
+	store, err := NewFileBanStore(path)
+	if err != nil {
+		t.Fatalf("NewFileBanStore: %s", err)
+	}
+	if err := store.Ban(client, peer, "test ban", time.Hour); err != nil {
+		t.Fatalf("Ban: %s", err)
+	}
+
+	// Simulate a restart: open a fresh FileBanStore against the same path.
+	reopened, err := NewFileBanStore(path)
+	if err != nil {
+		t.Fatalf("NewFileBanStore (reload): %s", err)
+	}
+
+	if _, banned := reopened.IsBanned(client, peer); !banned {
+		t.Fatal("ban should survive reload from disk")
+	}
+}
+
+func TestFileBanStoreDropsExpiredEntriesOnReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bans.json")
+
+	client := net.ParseIP("203.0.113.1")
+	peer := net.ParseIP("198.51.100.1")
+
+	store, err := NewFileBanStore(path)
+	if err != nil {
+		t.Fatalf("NewFileBanStore: %s", err)
+	}
+	if err := store.Ban(client, peer, "already expired", time.Nanosecond); err != nil {
+		t.Fatalf("Ban: %s", err)
+	}
+	time.Sleep(time.Millisecond)
+
+	reopened, err := NewFileBanStore(path)
+	if err != nil {
+		t.Fatalf("NewFileBanStore (reload): %s", err)
+	}
+
+	if _, banned := reopened.IsBanned(client, peer); banned {
+		t.Fatal("expired ban should not be loaded back in on reload")
+	}
+}
@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package turn
+
+import (
+	"net"
+	"sync"
+	"time"
+)
+
+// InMemoryBanStore is a BanStore that keeps bans in memory only. Entries do
+// not survive a restart; use NewFileBanStore for that.
+type InMemoryBanStore struct {
+	mu      sync.RWMutex
+	entries map[string]BanEntry
+}
+
+// NewInMemoryBanStore creates an empty InMemoryBanStore.
+func NewInMemoryBanStore() *InMemoryBanStore {
+	return &InMemoryBanStore{entries: map[string]BanEntry{}}
+}
+
+func banKey(clientIP, peerIP net.IP) string {
+	return ipString(clientIP) + "->" + ipString(peerIP)
+}
+
+// IsBanned implements BanStore.
+func (s *InMemoryBanStore) IsBanned(clientIP, peerIP net.IP) (string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := time.Now()
+	for _, entry := range s.entries {
+		if entry.expired(now) {
+			continue
+		}
+		if entry.matches(clientIP, peerIP) {
+			return entry.Reason, true
+		}
+	}
+	return "", false
+}
+
+// Ban implements BanStore.
+func (s *InMemoryBanStore) Ban(clientIP, peerIP net.IP, reason string, duration time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries[banKey(clientIP, peerIP)] = BanEntry{
+		ClientIP:  ipString(clientIP),
+		PeerIP:    ipString(peerIP),
+		Reason:    reason,
+		ExpiresAt: time.Now().Add(duration),
+	}
+	return nil
+}
+
+// Unban implements BanStore.
+func (s *InMemoryBanStore) Unban(clientIP, peerIP net.IP) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, banKey(clientIP, peerIP))
+	return nil
+}
+
+// List implements BanStore.
+func (s *InMemoryBanStore) List() []BanEntry {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := time.Now()
+	out := make([]BanEntry, 0, len(s.entries))
+	for _, entry := range s.entries {
+		if entry.expired(now) {
+			continue
+		}
+		out = append(out, entry)
+	}
+	return out
+}
+
+// Close implements BanStore. It is a no-op for InMemoryBanStore.
+func (s *InMemoryBanStore) Close() error {
+	return nil
+}
@@ -0,0 +1,117 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package allocation
+
+import (
+	"net"
+	"sync"
+	"time"
+)
+
+// ChannelBind represents a TURN channel binding (RFC 5766 Section 11) owned by
+// an Allocation.
+type ChannelBind struct {
+	Peer   net.Addr
+	Number uint16
+}
+
+// Allocation is a TURN relay allocation created on behalf of a client. It
+// tracks everything needed to relay traffic for that client as well as the
+// bookkeeping (byte counters, permissions, channel bindings) required for
+// introspection via the admin socket.
+type Allocation struct {
+	FiveTuple *FiveTuple
+	Username  string
+	RelayAddr net.Addr
+	CreatedAt time.Time
+
+	mu          sync.RWMutex
+	expiry      time.Time
+	permissions map[string]net.IP
+	channels    []ChannelBind
+	bytesIn     uint64
+	bytesOut    uint64
+}
+
+// NewAllocation creates a new Allocation that expires after lifetime.
+func NewAllocation(fiveTuple *FiveTuple, username string, relayAddr net.Addr, lifetime time.Duration) *Allocation {
+	return &Allocation{
+		FiveTuple:   fiveTuple,
+		Username:    username,
+		RelayAddr:   relayAddr,
+		CreatedAt:   time.Now(),
+		expiry:      time.Now().Add(lifetime),
+		permissions: map[string]net.IP{},
+	}
+}
+
+// Refresh extends the allocation's expiry by lifetime from now.
+func (a *Allocation) Refresh(lifetime time.Duration) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.expiry = time.Now().Add(lifetime)
+}
+
+// Expiry returns the time at which this allocation will be torn down.
+func (a *Allocation) Expiry() time.Time {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.expiry
+}
+
+// AddPermission installs a CreatePermission for peerIP.
+func (a *Allocation) AddPermission(peerIP net.IP) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.permissions[peerIP.String()] = peerIP
+}
+
+// Permissions returns the peer IPs this allocation currently has permission
+// to relay to.
+func (a *Allocation) Permissions() []net.IP {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	ips := make([]net.IP, 0, len(a.permissions))
+	for _, ip := range a.permissions {
+		ips = append(ips, ip)
+	}
+	return ips
+}
+
+// AddChannelBind installs a channel binding to peer under number.
+func (a *Allocation) AddChannelBind(peer net.Addr, number uint16) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.channels = append(a.channels, ChannelBind{Peer: peer, Number: number})
+}
+
+// ChannelBinds returns the active channel bindings for this allocation.
+func (a *Allocation) ChannelBinds() []ChannelBind {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	out := make([]ChannelBind, len(a.channels))
+	copy(out, a.channels)
+	return out
+}
+
+// AddBytesIn accumulates bytes relayed from the client to a peer.
+func (a *Allocation) AddBytesIn(n uint64) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.bytesIn += n
+}
+
+// AddBytesOut accumulates bytes relayed from a peer to the client.
+func (a *Allocation) AddBytesOut(n uint64) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.bytesOut += n
+}
+
+// BytesInOut returns the accumulated byte counters for this allocation.
+func (a *Allocation) BytesInOut() (in, out uint64) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.bytesIn, a.bytesOut
+}
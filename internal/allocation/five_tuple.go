@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package allocation
+
+import (
+	"fmt"
+	"net"
+)
+
+// Protocol is an enum representing the transport protocol used by a FiveTuple.
+type Protocol byte
+
+// Protocol enum.
+const (
+	UDP Protocol = iota
+	TCP
+)
+
+func (p Protocol) String() string {
+	switch p {
+	case UDP:
+		return "udp"
+	case TCP:
+		return "tcp"
+	default:
+		return "unknown"
+	}
+}
+
+// FiveTuple is the combination of protocol, source address and destination
+// address that uniquely identifies an Allocation.
+type FiveTuple struct {
+	Protocol Protocol
+	SrcAddr  net.Addr
+	DstAddr  net.Addr
+}
+
+// Equal asserts if two FiveTuples are equal.
+func (f *FiveTuple) Equal(b *FiveTuple) bool {
+	if b == nil {
+		return false
+	}
+	return f.Protocol == b.Protocol &&
+		f.SrcAddr.String() == b.SrcAddr.String() &&
+		f.DstAddr.String() == b.DstAddr.String()
+}
+
+func (f *FiveTuple) String() string {
+	return fmt.Sprintf("%s_%s_%s", f.Protocol, f.SrcAddr, f.DstAddr)
+}
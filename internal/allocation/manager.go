@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+// Package allocation manages the lifecycle of TURN relay allocations.
+package allocation
+
+import (
+	"net"
+	"sync"
+	"time"
+)
+
+// Manager keeps track of every active Allocation, keyed by its FiveTuple. It
+// is safe for concurrent use, which allows it to be read and mutated from the
+// packet-handling goroutines as well as out-of-band management surfaces such
+// as the admin socket.
+type Manager struct {
+	mu          sync.RWMutex
+	allocations map[string]*Allocation
+}
+
+// NewManager creates a new Manager.
+func NewManager() *Manager {
+	return &Manager{
+		allocations: map[string]*Allocation{},
+	}
+}
+
+// CreateAllocation creates and registers a new Allocation.
+func (m *Manager) CreateAllocation(fiveTuple *FiveTuple, username string, relayAddr net.Addr, lifetime time.Duration) *Allocation {
+	a := NewAllocation(fiveTuple, username, relayAddr, lifetime)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.allocations[fiveTuple.String()] = a
+
+	return a
+}
+
+// GetAllocation returns the Allocation for fiveTuple, or nil if none exists.
+func (m *Manager) GetAllocation(fiveTuple *FiveTuple) *Allocation {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.allocations[fiveTuple.String()]
+}
+
+// GetAllocationByUsername returns the first Allocation belonging to username,
+// or nil if none is found.
+func (m *Manager) GetAllocationByUsername(username string) *Allocation {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, a := range m.allocations {
+		if a.Username == username {
+			return a
+		}
+	}
+	return nil
+}
+
+// DeleteAllocation removes the Allocation for fiveTuple, if any.
+func (m *Manager) DeleteAllocation(fiveTuple *FiveTuple) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.allocations, fiveTuple.String())
+}
+
+// ListAllocations returns a snapshot of every currently active Allocation.
+// It exists primarily so out-of-band introspection tools (e.g. the admin
+// socket) can enumerate allocations without racing the hot packet path.
+func (m *Manager) ListAllocations() []*Allocation {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	out := make([]*Allocation, 0, len(m.allocations))
+	for _, a := range m.allocations {
+		out = append(out, a)
+	}
+	return out
+}
+
+// Close tears down the Manager. It currently performs no relay socket
+// cleanup since allocations created by this trimmed-down Manager do not yet
+// own real relay sockets.
+func (m *Manager) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.allocations = map[string]*Allocation{}
+	return nil
+}
@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package allocation
+
+import (
+	"net"
+	"testing"
+	"time"
+)
+
+func TestManagerCreateGetDeleteAllocation(t *testing.T) {
+	m := NewManager()
+
+	fiveTuple := &FiveTuple{
+		Protocol: UDP,
+		SrcAddr:  &net.UDPAddr{IP: net.ParseIP("203.0.113.1"), Port: 4000},
+		DstAddr:  &net.UDPAddr{IP: net.ParseIP("203.0.113.2"), Port: 3478},
+	}
+	relayAddr := &net.UDPAddr{IP: net.ParseIP("192.0.2.1"), Port: 50000}
+
+	a := m.CreateAllocation(fiveTuple, "alice", relayAddr, time.Minute)
+	if a == nil {
+		t.Fatal("expected a non-nil Allocation")
+	}
+
+	if got := m.GetAllocation(fiveTuple); got != a {
+		t.Fatalf("GetAllocation returned %v, want %v", got, a)
+	}
+	if got := m.GetAllocationByUsername("alice"); got != a {
+		t.Fatalf("GetAllocationByUsername returned %v, want %v", got, a)
+	}
+	if got := m.ListAllocations(); len(got) != 1 || got[0] != a {
+		t.Fatalf("ListAllocations returned %v, want [%v]", got, a)
+	}
+
+	m.DeleteAllocation(fiveTuple)
+	if got := m.GetAllocation(fiveTuple); got != nil {
+		t.Fatalf("expected nil after DeleteAllocation, got %v", got)
+	}
+	if got := m.ListAllocations(); len(got) != 0 {
+		t.Fatalf("expected no allocations after delete, got %d", len(got))
+	}
+}
+
+func TestManagerClose(t *testing.T) {
+	m := NewManager()
+
+	fiveTuple := &FiveTuple{
+		Protocol: UDP,
+		SrcAddr:  &net.UDPAddr{IP: net.ParseIP("203.0.113.1"), Port: 4000},
+		DstAddr:  &net.UDPAddr{IP: net.ParseIP("203.0.113.2"), Port: 3478},
+	}
+	relayAddr := &net.UDPAddr{IP: net.ParseIP("192.0.2.1"), Port: 50000}
+	m.CreateAllocation(fiveTuple, "alice", relayAddr, time.Minute)
+
+	if err := m.Close(); err != nil {
+		t.Fatalf("Close returned an error: %s", err)
+	}
+	if got := m.ListAllocations(); len(got) != 0 {
+		t.Fatalf("expected Close to clear all allocations, got %d remaining", len(got))
+	}
+}
@@ -0,0 +1,175 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package server
+
+import (
+	"container/list"
+	"net"
+	"sync"
+	"time"
+)
+
+// BindingRateLimiterConfig configures a BindingRateLimiter.
+type BindingRateLimiterConfig struct {
+	// RatePerSecond is the steady-state number of Binding requests a single
+	// prefix may send per second.
+	RatePerSecond float64
+
+	// Burst is the maximum number of Binding requests a prefix may send in a
+	// single burst. Defaults to RatePerSecond if zero.
+	Burst float64
+
+	// MaxEntries bounds the number of distinct prefixes tracked at once, so
+	// memory stays fixed even when an attacker spreads requests across many
+	// spoofed source addresses. Defaults to 65536 if zero.
+	MaxEntries int
+}
+
+// BindingRateLimiterMetrics is a point-in-time snapshot of a
+// BindingRateLimiter, exposed through the admin socket.
+type BindingRateLimiterMetrics struct {
+	Allowed uint64
+	Dropped uint64
+	Entries int
+	Evicted uint64
+}
+
+type bucket struct {
+	tokens     float64
+	lastRefill time.Time
+	elem       *list.Element
+}
+
+// BindingRateLimiter is a token-bucket rate limiter for STUN Binding
+// requests, keyed by source prefix (/32 for IPv4, /64 for IPv6) so that a
+// single attacker cannot use the Binding path for reflection/amplification
+// against a victim. Its storage is a bounded LRU so memory stays fixed under
+// attack from many spoofed sources.
+//
+// Eviction is victim-aware: when full, it prefers to evict a prefix whose
+// bucket is currently un-throttled (full of tokens) over one that is
+// actively being throttled. Otherwise an attacker could spray new source
+// prefixes purely to evict - and thus reset - the bucket that is presently
+// suppressing it.
+type BindingRateLimiter struct {
+	config BindingRateLimiterConfig
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	lru     *list.List // front = most recently used
+
+	allowed uint64
+	dropped uint64
+	evicted uint64
+}
+
+// NewBindingRateLimiter creates a BindingRateLimiter from config.
+func NewBindingRateLimiter(config BindingRateLimiterConfig) *BindingRateLimiter {
+	if config.Burst <= 0 {
+		config.Burst = config.RatePerSecond
+	}
+	if config.MaxEntries <= 0 {
+		config.MaxEntries = 65536
+	}
+
+	return &BindingRateLimiter{
+		config:  config,
+		buckets: map[string]*bucket{},
+		lru:     list.New(),
+	}
+}
+
+// Allow reports whether a Binding request from src should be processed. It
+// refills src's token bucket based on elapsed time, and consumes one token
+// if available.
+func (l *BindingRateLimiter) Allow(src net.IP) bool {
+	key := prefixKey(src)
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = l.createLocked(key, now)
+	}
+
+	elapsed := now.Sub(b.lastRefill).Seconds()
+	b.lastRefill = now
+	b.tokens += elapsed * l.config.RatePerSecond
+	if b.tokens > l.config.Burst {
+		b.tokens = l.config.Burst
+	}
+
+	l.lru.MoveToFront(b.elem)
+
+	if b.tokens < 1 {
+		l.dropped++
+		return false
+	}
+
+	b.tokens--
+	l.allowed++
+	return true
+}
+
+// createLocked inserts a fresh, full bucket for key, evicting an existing
+// entry first if the limiter is already at MaxEntries. l.mu must be held.
+func (l *BindingRateLimiter) createLocked(key string, now time.Time) *bucket {
+	if len(l.buckets) >= l.config.MaxEntries {
+		l.evictLocked()
+	}
+
+	b := &bucket{tokens: l.config.Burst, lastRefill: now}
+	b.elem = l.lru.PushFront(key)
+	l.buckets[key] = b
+	return b
+}
+
+// evictLocked removes one entry to make room for a new one. It scans from
+// the LRU tail for the first entry that is not currently throttled (a full
+// bucket, i.e. unused or recovered), since evicting it loses nothing but an
+// idle cache slot. If every recently-scanned entry is throttled, it falls
+// back to the true LRU tail so the scan stays bounded.
+func (l *BindingRateLimiter) evictLocked() {
+	const scanLimit = 8
+
+	elem := l.lru.Back()
+	victim := elem
+	for i := 0; elem != nil && i < scanLimit; i++ {
+		key := elem.Value.(string) //nolint:forcetypeassert
+		if b := l.buckets[key]; b.tokens >= l.config.Burst {
+			victim = elem
+			break
+		}
+		elem = elem.Prev()
+	}
+
+	key := victim.Value.(string) //nolint:forcetypeassert
+	l.lru.Remove(victim)
+	delete(l.buckets, key)
+	l.evicted++
+}
+
+// Metrics returns a snapshot of the limiter's counters.
+func (l *BindingRateLimiter) Metrics() BindingRateLimiterMetrics {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return BindingRateLimiterMetrics{
+		Allowed: l.allowed,
+		Dropped: l.dropped,
+		Entries: len(l.buckets),
+		Evicted: l.evicted,
+	}
+}
+
+// prefixKey returns the rate-limiting key for ip: the /32 for an IPv4
+// address, or the /64 for an IPv6 address.
+func prefixKey(ip net.IP) string {
+	if v4 := ip.To4(); v4 != nil {
+		return v4.String()
+	}
+	return ip.Mask(net.CIDRMask(64, 128)).String()
+}
@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package server
+
+import (
+	"net"
+	"testing"
+)
+
+func TestBindingRateLimiterAllowDeny(t *testing.T) {
+	l := NewBindingRateLimiter(BindingRateLimiterConfig{
+		RatePerSecond: 1,
+		Burst:         2,
+	})
+
+	ip := net.ParseIP("203.0.113.1")
+
+	if !l.Allow(ip) {
+		t.Fatal("first request within burst should be allowed")
+	}
+	if !l.Allow(ip) {
+		t.Fatal("second request within burst should be allowed")
+	}
+	if l.Allow(ip) {
+		t.Fatal("third request should exceed burst and be denied")
+	}
+
+	metrics := l.Metrics()
+	if metrics.Allowed != 2 || metrics.Dropped != 1 {
+		t.Fatalf("unexpected metrics: %+v", metrics)
+	}
+}
+
+func TestBindingRateLimiterEvictsWhenFull(t *testing.T) {
+	l := NewBindingRateLimiter(BindingRateLimiterConfig{
+		RatePerSecond: 1,
+		Burst:         1,
+		MaxEntries:    2,
+	})
+
+	a := net.ParseIP("203.0.113.1")
+	b := net.ParseIP("203.0.113.2")
+	c := net.ParseIP("203.0.113.3")
+
+	l.Allow(a)
+	l.Allow(b)
+
+	// a and b have each spent their single-token burst (no refill has
+	// happened yet), so both are throttled; adding c must still evict one of
+	// them (the LRU tail) rather than growing past MaxEntries.
+	l.Allow(c)
+
+	metrics := l.Metrics()
+	if metrics.Entries != 2 {
+		t.Fatalf("expected entries to stay bounded at MaxEntries, got %d", metrics.Entries)
+	}
+	if metrics.Evicted != 1 {
+		t.Fatalf("expected one eviction, got %d", metrics.Evicted)
+	}
+}
@@ -4,11 +4,57 @@
 package server
 
 import (
+	"math/rand"
+	"net"
+	"time"
+
 	"github.com/itzloop/turn/v2/internal/ipnet"
 	"github.com/pion/stun"
 )
 
-func handleBindingRequest(r Request, m *stun.Message) error {
+// KeyLookup resolves the long-term-credential key for a Binding request's
+// USERNAME attribute, mirroring the shape of the package turn AuthHandler
+// without importing it (which would create an import cycle).
+type KeyLookup func(username string, srcAddr net.Addr) (key []byte, ok bool)
+
+// StatsFunc is called with the number of bytes read from the request's
+// listener for every Binding request received (out is 0), and called again
+// with the number of bytes written when a response is sent (in is 0), so the
+// caller can maintain its own per-listener packet/byte counters.
+type StatsFunc func(localAddr string, in, out uint64)
+
+// BindingOptions configures abuse mitigation for handleBindingRequest.
+type BindingOptions struct {
+	// Limiter, if set, is consulted before every Binding request. Requests
+	// over the limit are dropped silently rather than answered with an
+	// error, since an error response would itself be usable for
+	// reflection/amplification.
+	Limiter *BindingRateLimiter
+
+	// RequireAuth, if true, drops Binding requests that carry no
+	// MESSAGE-INTEGRITY attribute, or whose MESSAGE-INTEGRITY does not
+	// verify against AuthKey. If AuthKey is nil, RequireAuth drops every
+	// Binding request, since none can be verified.
+	RequireAuth bool
+
+	// AuthKey resolves the key MESSAGE-INTEGRITY is checked against when
+	// RequireAuth is set.
+	AuthKey KeyLookup
+
+	// ResponseJitter, if non-zero, delays the success response by a random
+	// duration in [0, ResponseJitter) to make response timing less useful
+	// for probing the server. The delay is applied off the hot path (in its
+	// own goroutine) so it cannot stall other requests waiting on the same
+	// read loop.
+	ResponseJitter time.Duration
+
+	// Stats, if set, is notified of the inbound/outbound byte counts for
+	// every Binding request, so the caller can maintain its own per-listener
+	// packet/byte counters.
+	Stats StatsFunc
+}
+
+func handleBindingRequest(r Request, m *stun.Message, opts BindingOptions) error {
 	r.Log.Debugf("received BindingRequest from %s", r.SrcAddr.String())
 
 	ip, port, err := ipnet.AddrIPPort(r.SrcAddr)
@@ -16,10 +62,63 @@ func handleBindingRequest(r Request, m *stun.Message) error {
 		return err
 	}
 
+	if opts.Stats != nil {
+		opts.Stats(r.Conn.LocalAddr().String(), uint64(len(m.Raw)), 0)
+	}
+
+	if opts.RequireAuth {
+		var username stun.Username
+		if err := username.GetFrom(m); err != nil || opts.AuthKey == nil {
+			r.Log.Debugf("dropping unauthenticated BindingRequest from %s", r.SrcAddr.String())
+			return nil
+		}
+
+		key, ok := opts.AuthKey(username.String(), r.SrcAddr)
+		if !ok {
+			r.Log.Debugf("dropping BindingRequest from %s: unknown user", r.SrcAddr.String())
+			return nil
+		}
+
+		if err := stun.MessageIntegrity(key).Check(m); err != nil {
+			r.Log.Debugf("dropping BindingRequest from %s: MESSAGE-INTEGRITY check failed: %s", r.SrcAddr.String(), err)
+			return nil
+		}
+	}
+
+	if opts.Limiter != nil && !opts.Limiter.Allow(ip) {
+		r.Log.Debugf("dropping rate-limited BindingRequest from %s", r.SrcAddr.String())
+		return nil
+	}
+
 	attrs := buildMsg(m.TransactionID, stun.BindingSuccess, &stun.XORMappedAddress{
 		IP:   ip,
 		Port: port,
 	}, stun.Fingerprint)
 
-	return buildAndSend(r.Conn, r.SrcAddr, attrs...)
+	send := func() error {
+		if opts.Stats != nil {
+			if resp, err := stun.Build(attrs...); err == nil {
+				opts.Stats(r.Conn.LocalAddr().String(), 0, uint64(len(resp.Raw)))
+			}
+		}
+		return buildAndSend(r.Conn, r.SrcAddr, attrs...)
+	}
+
+	if opts.ResponseJitter == 0 {
+		return send()
+	}
+
+	// Apply the jitter off the hot path: a synchronous sleep here would
+	// serialize every Binding response behind this one if (as in the
+	// upstream read loop) requests are dispatched one at a time, turning the
+	// delay meant to blunt probing into a self-inflicted stall on the exact
+	// path it's supposed to protect during overload/abuse.
+	delay := time.Duration(rand.Int63n(int64(opts.ResponseJitter))) //nolint:gosec
+	go func() {
+		time.Sleep(delay)
+		if err := send(); err != nil {
+			r.Log.Warnf("failed to send jittered BindingResponse to %s: %s", r.SrcAddr.String(), err)
+		}
+	}()
+	return nil
 }
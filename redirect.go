@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package turn
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/pion/stun"
+)
+
+// AlternateServerResponse builds the RFC 5766 Section 15 error response
+// redirecting a client to alternate: a 300 (Try Alternate) Allocate error
+// response carrying an ALTERNATE-SERVER attribute. alternate is an
+// "ip:port" address that has already been resolved, such as
+// PeerServer.RedirectAddr (returned by Cluster.PickRedirectTarget). It is
+// intended for use by an Allocate handler once that target reports that the
+// local Server is overloaded - the exact moment a blocking DNS lookup would
+// be most costly, so alternate must already be a literal IP and this
+// function performs no name resolution of its own.
+func AlternateServerResponse(transactionID [stun.TransactionIDSize]byte, alternate string) (*stun.Message, error) {
+	host, portStr, err := net.SplitHostPort(alternate)
+	if err != nil {
+		return nil, err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return nil, fmt.Errorf("alternate server %q must be a resolved IP address, not a hostname", alternate)
+	}
+
+	port, err := net.LookupPort("udp", portStr)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := &stun.Message{TransactionID: transactionID}
+	if err := msg.Build(
+		stun.NewType(stun.MethodAllocate, stun.ClassErrorResponse),
+		&stun.ErrorCodeAttribute{Code: stun.CodeTryAlternate, Reason: []byte("Try Alternate")},
+		&stun.AlternateServer{IP: ip, Port: port},
+		stun.Fingerprint,
+	); err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
@@ -0,0 +1,294 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+// Package turn implements the TURN (RFC 5766) protocol on top of STUN.
+package turn
+
+import (
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/itzloop/turn/v2/internal/allocation"
+	iserver "github.com/itzloop/turn/v2/internal/server"
+	"github.com/pion/logging"
+)
+
+// AuthHandler is called whenever a client attempts to authenticate with the
+// server. It should return the key for that user (see GenerateAuthKey) and
+// true, or nil and false if the user is unknown.
+type AuthHandler func(username string, realm string, srcAddr net.Addr) (key []byte, ok bool)
+
+// PermissionHandler is called whenever a client requests permission to relay
+// to a given peer IP. Returning false rejects the request.
+type PermissionHandler func(clientAddr net.Addr, peerIP net.IP) bool
+
+// RelayAddressGenerator is used by a PacketConnConfig to allocate the
+// relay-side socket for a new allocation.
+type RelayAddressGenerator interface {
+	Validate() error
+	AllocatePacketConn(network string, requestedPort int) (net.PacketConn, net.Addr, error)
+}
+
+// PacketConnConfig is a single UDP listener and the configuration around it.
+type PacketConnConfig struct {
+	PacketConn            net.PacketConn
+	RelayAddressGenerator RelayAddressGenerator
+
+	// PermissionHandler, if set, is consulted before a CreatePermission or
+	// ChannelBind request against this listener is accepted.
+	PermissionHandler PermissionHandler
+}
+
+// ServerConfig configures a turn.Server.
+type ServerConfig struct {
+	Realm             string
+	AuthHandler       AuthHandler
+	PacketConnConfigs []PacketConnConfig
+	LoggerFactory     logging.LoggerFactory
+
+	// AdminListener, if set, enables a line-delimited JSON admin control
+	// socket for live introspection and management of this Server. See
+	// AdminSocket.
+	AdminListener net.Listener
+
+	// AuthMap, if set, backs the admin socket's addUser/removeUser/listUsers
+	// commands. It is typically also used as the source of AuthHandler via
+	// AuthMap.AuthHandler().
+	AuthMap *AuthMap
+
+	// BanStore is consulted by the PermissionHandler that the Server installs
+	// on every PacketConnConfig, i.e. before CreatePermission and ChannelBind
+	// requests are accepted. The Server does not check it for Allocate
+	// requests or Send/Data indications. Defaults to an InMemoryBanStore,
+	// or a FileBanStore at BanStorePath if that is set.
+	BanStore BanStore
+
+	// BanStorePath, if set and BanStore is nil, makes the Server use a
+	// FileBanStore at this path, re-loading any bans persisted by a
+	// previous run.
+	BanStorePath string
+
+	// BanTriggers configures automatic ban population. Zero value disables
+	// all automatic triggers; use DefaultBanTriggers for sane defaults.
+	BanTriggers BanTriggers
+
+	// Cluster, if set, makes this Server part of a pool of TURN servers that
+	// discover each other via DNS SRV records, and exposes that peer view
+	// through the admin socket. Given a LoadReporter, PickAllocateRedirect
+	// returns a lightly loaded peer for use with AlternateServerResponse; the
+	// Server does not itself answer Allocate requests with ALTERNATE-SERVER.
+	// The Server takes ownership of Cluster and closes it from Close().
+	Cluster *Cluster
+
+	// BindingRateLimit configures internal/server's per-prefix token-bucket
+	// limiter for STUN Binding requests. Zero value disables rate limiting.
+	BindingRateLimit BindingRateLimit
+
+	// BindingRequireAuth, if true, makes internal/server drop Binding
+	// requests whose MESSAGE-INTEGRITY is missing or does not verify,
+	// rather than answering them. Verification uses the same AuthHandler as
+	// Allocate; if AuthHandler is not set, every Binding request is dropped.
+	BindingRequireAuth bool
+
+	// BindingResponseJitter, if non-zero, delays each Binding success
+	// response by a random duration in [0, BindingResponseJitter) to make
+	// response timing less useful for probing the server.
+	BindingResponseJitter time.Duration
+}
+
+// Server is an instance of the Pion TURN server.
+type Server struct {
+	log               logging.LeveledLogger
+	realm             string
+	authHandler       AuthHandler
+	packetConnConfigs []PacketConnConfig
+
+	allocationManager *allocation.Manager
+	admin             *AdminSocket
+	authMap           *AuthMap
+
+	banStore BanStore
+	banAuto  *banAutomation
+
+	cluster *Cluster
+
+	bindingOpts iserver.BindingOptions
+
+	statsMu       sync.RWMutex
+	listenerStats map[string]*listenerStats
+}
+
+// NewServer creates a new turn.Server.
+func NewServer(config ServerConfig) (*Server, error) {
+	loggerFactory := config.LoggerFactory
+	if loggerFactory == nil {
+		loggerFactory = logging.NewDefaultLoggerFactory()
+	}
+
+	banStore := config.BanStore
+	if banStore == nil {
+		if config.BanStorePath != "" {
+			fileStore, err := NewFileBanStore(config.BanStorePath)
+			if err != nil {
+				return nil, err
+			}
+			banStore = fileStore
+		} else {
+			banStore = NewInMemoryBanStore()
+		}
+	}
+
+	s := &Server{
+		log:               loggerFactory.NewLogger("turn"),
+		realm:             config.Realm,
+		packetConnConfigs: config.PacketConnConfigs,
+		allocationManager: allocation.NewManager(),
+		listenerStats:     map[string]*listenerStats{},
+		authMap:           config.AuthMap,
+		banStore:          banStore,
+		banAuto:           newBanAutomation(config.BanTriggers),
+		cluster:           config.Cluster,
+	}
+
+	s.authHandler = s.wrapAuthHandler(config.AuthHandler)
+
+	s.bindingOpts = iserver.BindingOptions{
+		Limiter:        newBindingRateLimiter(config.BindingRateLimit),
+		RequireAuth:    config.BindingRequireAuth,
+		AuthKey:        s.bindingAuthKey,
+		ResponseJitter: config.BindingResponseJitter,
+		Stats:          s.recordBindingStats,
+	}
+
+	for i, pcc := range config.PacketConnConfigs {
+		s.listenerStats[pcc.PacketConn.LocalAddr().String()] = &listenerStats{}
+		s.packetConnConfigs[i].PermissionHandler = s.wrapPermissionHandler(pcc.PermissionHandler)
+	}
+
+	if config.AdminListener != nil {
+		admin, err := newAdminSocket(config.AdminListener, s)
+		if err != nil {
+			return nil, err
+		}
+		s.admin = admin
+	}
+
+	return s, nil
+}
+
+// Close shuts the server down, including its admin socket (if any) and every
+// active allocation. Every subsystem is closed even if an earlier one
+// errors, so one failure can't leak the others (e.g. a stuck admin.Close
+// must not leave the cluster's background SRV-refresh goroutine running);
+// any errors are combined with errors.Join.
+func (s *Server) Close() error {
+	var errs []error
+
+	if s.admin != nil {
+		if err := s.admin.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if s.cluster != nil {
+		if err := s.cluster.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if err := s.allocationManager.Close(); err != nil {
+		errs = append(errs, err)
+	}
+
+	if err := s.banStore.Close(); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// PickAllocateRedirect returns a peer TURN server to redirect an incoming
+// Allocate request to via ALTERNATE-SERVER (see AlternateServerResponse), if
+// this Server is part of a Cluster and that Cluster's LoadReporter reports
+// the local Server is over its RedirectThresholds. ok is false if no Cluster
+// is configured or redirection should not happen right now.
+func (s *Server) PickAllocateRedirect() (target string, ok bool) {
+	if s.cluster == nil {
+		return "", false
+	}
+	return s.cluster.PickRedirectTarget()
+}
+
+// bindingRateLimiterStats returns a snapshot of the Binding request rate
+// limiter's counters, or nil if BindingRateLimit was not configured.
+func (s *Server) bindingRateLimiterStats() *BindingRateLimiterStats {
+	if s.bindingOpts.Limiter == nil {
+		return nil
+	}
+	metrics := s.bindingOpts.Limiter.Metrics()
+	return &BindingRateLimiterStats{
+		Allowed: metrics.Allowed,
+		Dropped: metrics.Dropped,
+		Entries: metrics.Entries,
+		Evicted: metrics.Evicted,
+	}
+}
+
+// wrapAuthHandler returns an AuthHandler that feeds failed attempts into the
+// automatic ban-escalation logic in BanTriggers before deferring to handler.
+func (s *Server) wrapAuthHandler(handler AuthHandler) AuthHandler {
+	if handler == nil {
+		return nil
+	}
+	return func(username, realm string, srcAddr net.Addr) ([]byte, bool) {
+		key, ok := handler(username, realm, srcAddr)
+		if !ok {
+			srcIP, _, err := net.SplitHostPort(srcAddr.String())
+			if err != nil {
+				srcIP = srcAddr.String()
+			}
+			s.banAuto.recordFailedAuth(s.banStore, net.ParseIP(srcIP))
+		}
+		return key, ok
+	}
+}
+
+// bindingAuthKey adapts s.authHandler to iserver.KeyLookup, so
+// handleBindingRequest can verify a Binding request's MESSAGE-INTEGRITY
+// against the same long-term-credential store as Allocate. It returns
+// ok=false (which drops the request) if no AuthHandler is configured.
+func (s *Server) bindingAuthKey(username string, srcAddr net.Addr) ([]byte, bool) {
+	if s.authHandler == nil {
+		return nil, false
+	}
+	return s.authHandler(username, s.realm, srcAddr)
+}
+
+// wrapPermissionHandler returns a PermissionHandler that first consults the
+// Server's BanStore, then defers to handler (if set), feeding any rejection
+// into the automatic ban-escalation logic in BanTriggers.
+func (s *Server) wrapPermissionHandler(handler PermissionHandler) PermissionHandler {
+	return func(clientAddr net.Addr, peerIP net.IP) bool {
+		clientIP, _, err := net.SplitHostPort(clientAddr.String())
+		if err != nil {
+			clientIP = clientAddr.String()
+		}
+		clientIPAddr := net.ParseIP(clientIP)
+
+		if _, banned := s.banStore.IsBanned(clientIPAddr, peerIP); banned {
+			return false
+		}
+
+		if handler == nil {
+			return true
+		}
+
+		allowed := handler(clientAddr, peerIP)
+		if !allowed {
+			s.banAuto.recordPermissionReject(s.banStore, clientIPAddr, peerIP)
+		}
+		return allowed
+	}
+}